@@ -31,6 +31,7 @@
 package glicko
 
 import (
+	"errors"
 	"math"
 )
 
@@ -44,10 +45,21 @@ const (
 	DefaultTau        = 0.75
 	DefaultFactor     = 173.7178
 	DefaultEpsilon    = 0.000001
+	// Default cap on the number of iterations the volatility root search is
+	// allowed to run before giving up.
+	DefaultMaxIterations = 100
 	// Default rating period duration in seconds.
 	DefaultDuration = 60 * 60 * 24 * 7
 )
 
+// ErrVolatilityNotConverged is returned by [Estimator.Estimate] and
+// [Estimator.EstimateBatch] when the search for the new sigma cannot bracket
+// the root, or the Illinois iteration that narrows it does not converge,
+// within [Estimator.MaxIterations] iterations.
+// This guards against pathological inputs (a huge delta, a tiny tau) that
+// would otherwise make the search spin forever.
+var ErrVolatilityNotConverged = errors.New("glicko: volatility iteration did not converge")
+
 // Converter performs conversions between the Glicko-2 and traditional
 // "Elo-style" rating scales.  Internally all calculations to estimate the
 // player's [Strength] are performed using the Glicko-2 scaled values.
@@ -82,6 +94,20 @@ func (c Converter) Phi2Deviation(phi float64) float64 {
 	return phi * c.Factor
 }
 
+// ConfidenceInterval returns the Elo-scale bounds of the confidence interval
+// around the player's rating at the given z-score, i.e. [mu-z*phi, mu+z*phi]
+// converted back to the "Elo-style" scale.
+func (c Converter) ConfidenceInterval(s Strength, z float64) (lo, hi float64) {
+	return c.Mu2Rating(s.Mu - z*s.Phi), c.Mu2Rating(s.Mu + z*s.Phi)
+}
+
+// Rating95 returns the Elo-scale bounds of the player's 95% confidence
+// interval, i.e. mu-2*phi to mu+2*phi, as described in the original
+// Glicko-2 paper.
+func (c Converter) Rating95(s Strength) (lo, hi float64) {
+	return c.ConfidenceInterval(s, 2)
+}
+
 // Strength represents a player's strength estimate.
 type Strength struct {
 	Mu    float64
@@ -104,7 +130,12 @@ type Outcome struct {
 
 // Internal helper function.
 func (o Outcome) g() float64 {
-	return 1 / math.Sqrt(1+(3*pow2(o.Phi)/pow2(math.Pi)))
+	return g(o.Phi)
+}
+
+// Internal helper function.
+func g(phi float64) float64 {
+	return 1 / math.Sqrt(1+(3*pow2(phi)/pow2(math.Pi)))
 }
 
 // Internal helper function.
@@ -112,6 +143,44 @@ func (o Outcome) e(g, mu float64) float64 {
 	return 1 / (1 + math.Exp(-g*(mu-o.Mu)))
 }
 
+// Opponent represents a single opponent encountered in a [TeamOutcome], or,
+// for team games, an opposing team already pooled into a single virtual
+// opponent with [AggregateTeam].
+type Opponent struct {
+	// Opponent's mu.
+	Mu float64
+	// Opponent's phi.
+	Phi float64
+	// Opponent's rank in the game.  Lower ranks finish ahead of higher
+	// ranks, e.g. 1 for first place, 2 for second place, and so on.  Equal
+	// ranks are treated as a tie.
+	Rank int
+}
+
+// TeamOutcome represents an N-player free-for-all or team game from a
+// single player's perspective: every [Opponent] the player faced, and the
+// player's own rank in the game.
+type TeamOutcome struct {
+	Opponents []Opponent
+	// This player's rank in the game.  Lower ranks finish ahead of higher
+	// ranks, e.g. 1 for first place, 2 for second place, and so on.
+	Rank int
+}
+
+// AggregateTeam pools a team's players into a single virtual [Opponent]
+// with the given rank, matching the pooled-opponent trick used by
+// multi-player Glicko variants: the virtual mu is the mean of the team's
+// mu, and the virtual phi is the root mean square of the team's phi.
+func AggregateTeam(team []Strength, rank int) Opponent {
+	var sumMu, sumPhi2 float64
+	for _, s := range team {
+		sumMu += s.Mu
+		sumPhi2 += pow2(s.Phi)
+	}
+	n := float64(len(team))
+	return Opponent{Mu: sumMu / n, Phi: math.Sqrt(sumPhi2 / n), Rank: rank}
+}
+
 // Estimator performs calculations of the player's strength.
 type Estimator struct {
 	// Rating period duration in seconds.  Use [DefaultDuration] constant for
@@ -133,6 +202,11 @@ type Estimator struct {
 	Tau float64
 	// System variable.  Use [DefaultEpsilon] constant for the recommended value.
 	Epsilon float64
+	// Upper bound on the number of iterations the volatility root search may
+	// run, both while bracketing the root and while narrowing it with the
+	// Illinois method.  Use [DefaultMaxIterations] constant for the
+	// recommended value.
+	MaxIterations int
 }
 
 // Estimate updates the player's [Strength] by analyzing:
@@ -141,8 +215,11 @@ type Estimator struct {
 //   - periodFraction: fraction of a rating period that has elapsed since the
 //     last rating update.
 //
-// The result of this function is validated.
-func (e Estimator) Estimate(s *Strength, o Outcome, periodFraction float64) {
+// The result of this function is validated.  Estimate returns
+// [ErrVolatilityNotConverged] if the volatility root search does not
+// converge within [Estimator.MaxIterations] iterations, in which case s is
+// left unmodified.
+func (e Estimator) Estimate(s *Strength, o Outcome, periodFraction float64) error {
 	// Calculate V and Delta.
 	G := o.g()
 	E := o.e(G, s.Mu)
@@ -150,7 +227,11 @@ func (e Estimator) Estimate(s *Strength, o Outcome, periodFraction float64) {
 	Delta := V * G * (o.Score - E)
 
 	// Calculate new sigma.
-	s.Sigma = e.sigmaPrime(*s, Delta, V)
+	sigma, err := e.sigmaPrime(*s, Delta, V)
+	if err != nil {
+		return err
+	}
+	s.Sigma = sigma
 
 	// Calculate new phi.
 	phiStar := math.Sqrt(pow2(s.Phi) + pow2(s.Sigma)*periodFraction)
@@ -160,6 +241,134 @@ func (e Estimator) Estimate(s *Strength, o Outcome, periodFraction float64) {
 	s.Mu = s.Mu + pow2(s.Phi)*(Delta/V)
 
 	e.Validate(s)
+	return nil
+}
+
+// EstimateBatch updates the player's [Strength] by analyzing:
+//   - s: player's [Strength] at the onset of the rating period.
+//   - outcomes: every [Outcome] the player was involved in during a single
+//     rating period.
+//   - periodFraction: fraction of a rating period that has elapsed since the
+//     last rating update.
+//
+// Unlike [Estimator.Estimate], which folds outcomes into the player's
+// [Strength] one at a time, EstimateBatch computes v and delta as a single
+// sum over every outcome before updating phi, sigma and mu, exactly as
+// described in the paper.  Because the update is non-linear, processing
+// outcomes sequentially (as [Estimator.Estimate] does for online use)
+// yields slightly different results than processing them as a batch at the
+// end of the rating period.  Prefer EstimateBatch whenever outcomes are
+// collected and applied together; prefer [Estimator.Estimate] when an
+// outcome must be reflected in the rating immediately.
+//
+// If outcomes is empty, only phi is inflated to reflect the growing
+// uncertainty about the player's strength, matching the paper's guidance
+// for players who did not compete during the rating period.
+//
+// The result of this function is validated.  EstimateBatch returns
+// [ErrVolatilityNotConverged] if the volatility root search does not
+// converge within [Estimator.MaxIterations] iterations, in which case s is
+// left unmodified.
+func (e Estimator) EstimateBatch(s *Strength, outcomes []Outcome, periodFraction float64) error {
+	if len(outcomes) == 0 {
+		s.Phi = math.Sqrt(pow2(s.Phi) + pow2(s.Sigma)*periodFraction)
+		e.Validate(s)
+		return nil
+	}
+
+	// Calculate V and Delta over every outcome in the period.
+	var sumGE, sumG2EE float64
+	for _, o := range outcomes {
+		G := o.g()
+		E := o.e(G, s.Mu)
+		sumG2EE += pow2(G) * E * (1 - E)
+		sumGE += G * (o.Score - E)
+	}
+	V := 1 / sumG2EE
+	Delta := V * sumGE
+
+	// Calculate new sigma.
+	sigma, err := e.sigmaPrime(*s, Delta, V)
+	if err != nil {
+		return err
+	}
+	s.Sigma = sigma
+
+	// Calculate new phi.
+	phiStar := math.Sqrt(pow2(s.Phi) + pow2(s.Sigma)*periodFraction)
+	s.Phi = 1 / math.Sqrt(1/pow2(phiStar)+1/V)
+
+	// Calculate new mu.
+	s.Mu = s.Mu + pow2(s.Phi)*sumGE
+
+	e.Validate(s)
+	return nil
+}
+
+// EstimateMulti updates the player's [Strength] from the outcome of an
+// N-player free-for-all or team game.  It treats the game as a set of
+// pairwise virtual [Outcome]s: for every [Opponent] the player faced, it
+// synthesizes an [Outcome] scored 1 if the player finished ahead of that
+// opponent, 0 if behind, and 0.5 if tied, then feeds the resulting outcomes
+// through [Estimator.EstimateBatch].  For team games, pool each opposing
+// team into a single [Opponent] with [AggregateTeam] before building the
+// [TeamOutcome].
+//
+// The result of this function is validated.  EstimateMulti returns
+// [ErrVolatilityNotConverged] if the volatility root search does not
+// converge within [Estimator.MaxIterations] iterations, in which case s is
+// left unmodified.
+func (e Estimator) EstimateMulti(s *Strength, o TeamOutcome, periodFraction float64) error {
+	outcomes := make([]Outcome, len(o.Opponents))
+	for i, opp := range o.Opponents {
+		score := 0.5
+		switch {
+		case o.Rank < opp.Rank:
+			score = 1
+		case o.Rank > opp.Rank:
+			score = 0
+		}
+		outcomes[i] = Outcome{Mu: opp.Mu, Phi: opp.Phi, Score: score}
+	}
+	return e.EstimateBatch(s, outcomes, periodFraction)
+}
+
+// WinProbability returns the expected score of player a against player b,
+// i.e. the probability that a wins a single game between the two, using the
+// standard two-player Glicko-2 expected-score formula: the difference
+// between the players' mu, weighted by g of their combined phi.
+func (e Estimator) WinProbability(a, b Strength) float64 {
+	phi := math.Sqrt(pow2(a.Phi) + pow2(b.Phi))
+	return 1 / (1 + math.Exp(-g(phi)*(a.Mu-b.Mu)))
+}
+
+// DrawProbability returns the probability of a draw between player a and
+// player b.  It carves a symmetric draw region out of the expected-score
+// model used by [Estimator.WinProbability], in the style of the Rao-Kupper
+// model for win/draw/loss outcomes: drawWidth widens the region around an
+// even match that counts as a draw rather than a win or a loss.  A
+// drawWidth of 0 rules out draws entirely: the result is 0 for any two
+// players, evenly matched or not.
+func (e Estimator) DrawProbability(a, b Strength, drawWidth float64) float64 {
+	phi := math.Sqrt(pow2(a.Phi) + pow2(b.Phi))
+	G := g(phi)
+	winA := 1 / (1 + math.Exp(-(G*(a.Mu-b.Mu) - drawWidth)))
+	winB := 1 / (1 + math.Exp(-(G*(b.Mu-a.Mu) - drawWidth)))
+	return 1 - winA - winB
+}
+
+// Decay ages a player's [Strength] who did not compete during one or more
+// rating periods, applying the paper's Step 6 in isolation: phi grows to
+// reflect the increased uncertainty while sigma is left unchanged.  Unlike
+// [Estimator.Estimate], which only decays phi as a side effect of processing
+// an outcome, Decay lets services run a periodic sweep over idle accounts
+// between rating periods.  Decay(s, n) has the same effect as
+// [Estimator.EstimateBatch] with no outcomes and a periodFraction of n.
+//
+// The result of this function is validated.
+func (e Estimator) Decay(s *Strength, periods float64) {
+	s.Phi = math.Sqrt(pow2(s.Phi) + pow2(s.Sigma)*periods)
+	e.Validate(s)
 }
 
 // Validate validates the [Srength] by checking if the values satisfy the
@@ -184,28 +393,39 @@ func (e Estimator) Validate(s *Strength) {
 	}
 }
 
-// Internal helper function.
-func (e Estimator) sigmaPrime(s Strength, delta, v float64) float64 {
+// Internal helper function.  Returns [ErrVolatilityNotConverged] if it
+// cannot bracket or narrow the root within [Estimator.MaxIterations]
+// iterations.
+func (e Estimator) sigmaPrime(s Strength, delta, v float64) (float64, error) {
 	a := math.Log(pow2(s.Sigma))
 
 	B := 0.0
 	if pow2(delta) > pow2(s.Phi)+v {
 		B = math.Log(pow2(delta) - pow2(s.Phi) - v)
 	} else {
-		for k := 1.0; ; k++ {
-			B = a - k*e.Tau
+		found := false
+		for k := 1; k <= e.MaxIterations; k++ {
+			B = a - float64(k)*e.Tau
 
-			if e.f(delta, s.Phi, v, a, a-k*e.Tau) > 0 {
+			if e.f(delta, s.Phi, v, a, B) > 0 {
+				found = true
 				break
 			}
 		}
+		if !found {
+			return 0, ErrVolatilityNotConverged
+		}
 	}
 
 	A := a
 	fA := e.f(delta, s.Phi, v, a, A)
 	fB := e.f(delta, s.Phi, v, a, B)
 
-	for math.Abs(B-A) > e.Epsilon {
+	for i := 0; math.Abs(B-A) > e.Epsilon; i++ {
+		if i >= e.MaxIterations {
+			return 0, ErrVolatilityNotConverged
+		}
+
 		C := A + (A-B)*fA/(fB-fA)
 		fC := e.f(delta, s.Phi, v, a, C)
 
@@ -219,7 +439,7 @@ func (e Estimator) sigmaPrime(s Strength, delta, v float64) float64 {
 		B = C
 		fB = fC
 	}
-	return math.Exp(A / 2)
+	return math.Exp(A / 2), nil
 }
 
 // Internal helper function.
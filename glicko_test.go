@@ -1,6 +1,10 @@
 package glicko
 
-import "testing"
+import (
+	"errors"
+	"math"
+	"testing"
+)
 
 func TestEstimate(t *testing.T) {
 	c := Converter{
@@ -42,10 +46,13 @@ func TestEstimate(t *testing.T) {
 			MinMu: c.Rating2Mu(10), MaxMu: c.Rating2Mu(5000),
 			MinPhi: c.Deviation2Phi(50), MaxPhi: c.Deviation2Phi(DefaultDeviation),
 			MinSigma: 0.04, MaxSigma: 0.08, Tau: DefaultTau, Epsilon: DefaultEpsilon,
+			MaxIterations: DefaultMaxIterations,
 		}
 
 		for i := range tc.outcomes {
-			e.Estimate(&tc.input, tc.outcomes[i], 1)
+			if err := e.Estimate(&tc.input, tc.outcomes[i], 1); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
 		}
 
 		if tc.input != tc.expected {
@@ -54,6 +61,279 @@ func TestEstimate(t *testing.T) {
 	}
 }
 
+func TestEstimateBatch(t *testing.T) {
+	c := Converter{
+		Rating:    DefaultRating,
+		Deviation: DefaultDeviation,
+		Factor:    DefaultFactor,
+	}
+
+	testcases := []struct {
+		outcomes []Outcome
+		input    Strength
+		expected Strength
+	}{
+		{
+			[]Outcome{
+				{Mu: c.Rating2Mu(1400), Phi: c.Deviation2Phi(30), Score: 1},
+				{Mu: c.Rating2Mu(1550), Phi: c.Deviation2Phi(100), Score: 0},
+				{Mu: c.Rating2Mu(1700), Phi: c.Deviation2Phi(300), Score: 0},
+			},
+			Strength{
+				Mu:    c.Rating2Mu(1500),
+				Phi:   c.Deviation2Phi(200),
+				Sigma: 0.06,
+			},
+			// Matches the worked example from the original Glicko-2 paper
+			// (new rating 1464.06, new deviation 151.52, new volatility
+			// 0.05999), since EstimateBatch processes every outcome as a
+			// single non-linear update instead of sequentially.
+			Strength{
+				Mu:    -0.20694091301281417,
+				Phi:   0.8721990750445217,
+				Sigma: 0.05999096598469106,
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		e := Estimator{
+			MinMu: c.Rating2Mu(10), MaxMu: c.Rating2Mu(5000),
+			MinPhi: c.Deviation2Phi(50), MaxPhi: c.Deviation2Phi(DefaultDeviation),
+			MinSigma: 0.04, MaxSigma: 0.08, Tau: DefaultTau, Epsilon: DefaultEpsilon,
+			MaxIterations: DefaultMaxIterations,
+		}
+
+		if err := e.EstimateBatch(&tc.input, tc.outcomes, 1); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if tc.input != tc.expected {
+			t.Fatalf("expected: %v, got: %v", tc.expected, tc.input)
+		}
+	}
+}
+
+func TestEstimateBatchNoOutcomes(t *testing.T) {
+	c := Converter{
+		Rating:    DefaultRating,
+		Deviation: DefaultDeviation,
+		Factor:    DefaultFactor,
+	}
+	e := Estimator{
+		MinMu: c.Rating2Mu(10), MaxMu: c.Rating2Mu(5000),
+		MinPhi: c.Deviation2Phi(50), MaxPhi: c.Deviation2Phi(DefaultDeviation),
+		MinSigma: 0.04, MaxSigma: 0.08, Tau: DefaultTau, Epsilon: DefaultEpsilon,
+		MaxIterations: DefaultMaxIterations,
+	}
+
+	input := Strength{Mu: c.Rating2Mu(1500), Phi: c.Deviation2Phi(200), Sigma: 0.06}
+	expected := Strength{
+		Mu:    input.Mu,
+		Phi:   math.Sqrt(pow2(input.Phi) + pow2(input.Sigma)),
+		Sigma: input.Sigma,
+	}
+
+	if err := e.EstimateBatch(&input, nil, 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if input != expected {
+		t.Fatalf("expected: %v, got: %v", expected, input)
+	}
+}
+
+func TestConfidenceInterval(t *testing.T) {
+	c := Converter{
+		Rating:    DefaultRating,
+		Deviation: DefaultDeviation,
+		Factor:    DefaultFactor,
+	}
+
+	s := Strength{Mu: c.Rating2Mu(1500), Phi: c.Deviation2Phi(200)}
+
+	lo, hi := c.Rating95(s)
+	if lo != 1100 || hi != 1900 {
+		t.Fatalf("expected: [1100, 1900], got: [%v, %v]", lo, hi)
+	}
+}
+
+func TestWinProbability(t *testing.T) {
+	c := Converter{
+		Rating:    DefaultRating,
+		Deviation: DefaultDeviation,
+		Factor:    DefaultFactor,
+	}
+	e := Estimator{Tau: DefaultTau, Epsilon: DefaultEpsilon}
+
+	evenMatch := c.Rating2Mu(1500)
+	a := Strength{Mu: evenMatch, Phi: c.Deviation2Phi(200)}
+	b := Strength{Mu: evenMatch, Phi: c.Deviation2Phi(200)}
+
+	if p := e.WinProbability(a, b); p != 0.5 {
+		t.Fatalf("expected evenly matched players to have a 0.5 win probability, got: %v", p)
+	}
+
+	a.Mu = c.Rating2Mu(1700)
+	if p := e.WinProbability(a, b); p <= 0.5 {
+		t.Fatalf("expected the higher rated player to have a win probability above 0.5, got: %v", p)
+	}
+}
+
+func TestDrawProbability(t *testing.T) {
+	c := Converter{
+		Rating:    DefaultRating,
+		Deviation: DefaultDeviation,
+		Factor:    DefaultFactor,
+	}
+	e := Estimator{Tau: DefaultTau, Epsilon: DefaultEpsilon}
+
+	evenMatch := c.Rating2Mu(1500)
+	a := Strength{Mu: evenMatch, Phi: c.Deviation2Phi(200)}
+	b := Strength{Mu: evenMatch, Phi: c.Deviation2Phi(200)}
+
+	if p := e.DrawProbability(a, b, 0); p != 0 {
+		t.Fatalf("expected a draw width of 0 to rule out draws between even players, got: %v", p)
+	}
+
+	if p := e.DrawProbability(a, b, 0.5); p <= 0 {
+		t.Fatalf("expected a positive draw width to yield a positive draw probability, got: %v", p)
+	}
+}
+
+func TestDecay(t *testing.T) {
+	c := Converter{
+		Rating:    DefaultRating,
+		Deviation: DefaultDeviation,
+		Factor:    DefaultFactor,
+	}
+	e := Estimator{
+		MinPhi: c.Deviation2Phi(50), MaxPhi: c.Deviation2Phi(DefaultDeviation),
+		MinSigma: 0.04, MaxSigma: 0.08, Tau: DefaultTau, Epsilon: DefaultEpsilon,
+	}
+
+	s := Strength{Mu: c.Rating2Mu(1500), Phi: c.Deviation2Phi(200), Sigma: 0.06}
+	expected := Strength{
+		Mu:    s.Mu,
+		Phi:   math.Sqrt(pow2(s.Phi) + pow2(s.Sigma)*2),
+		Sigma: s.Sigma,
+	}
+
+	e.Decay(&s, 2)
+
+	if s != expected {
+		t.Fatalf("expected: %v, got: %v", expected, s)
+	}
+}
+
+func TestDecayCapsPhi(t *testing.T) {
+	c := Converter{
+		Rating:    DefaultRating,
+		Deviation: DefaultDeviation,
+		Factor:    DefaultFactor,
+	}
+	e := Estimator{
+		MinPhi: c.Deviation2Phi(50), MaxPhi: c.Deviation2Phi(DefaultDeviation),
+		MinSigma: 0.04, MaxSigma: 0.08, Tau: DefaultTau, Epsilon: DefaultEpsilon,
+	}
+
+	s := Strength{Mu: c.Rating2Mu(1500), Phi: c.Deviation2Phi(300), Sigma: 0.06}
+
+	e.Decay(&s, 10000)
+
+	if s.Phi != e.MaxPhi {
+		t.Fatalf("expected phi to be capped at %v, got: %v", e.MaxPhi, s.Phi)
+	}
+}
+
+func TestEstimateVolatilityNotConverged(t *testing.T) {
+	c := Converter{
+		Rating:    DefaultRating,
+		Deviation: DefaultDeviation,
+		Factor:    DefaultFactor,
+	}
+	e := Estimator{
+		MinMu: c.Rating2Mu(10), MaxMu: c.Rating2Mu(5000),
+		MinPhi: c.Deviation2Phi(50), MaxPhi: c.Deviation2Phi(DefaultDeviation),
+		MinSigma: 0.04, MaxSigma: 0.08, Tau: DefaultTau, Epsilon: DefaultEpsilon,
+		MaxIterations: 1,
+	}
+
+	s := Strength{Mu: c.Rating2Mu(1500), Phi: c.Deviation2Phi(200), Sigma: 0.06}
+	o := Outcome{Mu: c.Rating2Mu(1400), Phi: c.Deviation2Phi(30), Score: 1}
+
+	err := e.Estimate(&s, o, 1)
+	if !errors.Is(err, ErrVolatilityNotConverged) {
+		t.Fatalf("expected: %v, got: %v", ErrVolatilityNotConverged, err)
+	}
+}
+
+func TestEstimateMulti(t *testing.T) {
+	c := Converter{
+		Rating:    DefaultRating,
+		Deviation: DefaultDeviation,
+		Factor:    DefaultFactor,
+	}
+	e := Estimator{
+		MinMu: c.Rating2Mu(10), MaxMu: c.Rating2Mu(5000),
+		MinPhi: c.Deviation2Phi(50), MaxPhi: c.Deviation2Phi(DefaultDeviation),
+		MinSigma: 0.04, MaxSigma: 0.08, Tau: DefaultTau, Epsilon: DefaultEpsilon,
+		MaxIterations: DefaultMaxIterations,
+	}
+
+	// A free-for-all with the same three opponents as TestEstimateBatch:
+	// the player beats the weakest, loses to the other two, which is
+	// equivalent to finishing 2nd out of 4.
+	s := Strength{Mu: c.Rating2Mu(1500), Phi: c.Deviation2Phi(200), Sigma: 0.06}
+	o := TeamOutcome{
+		Opponents: []Opponent{
+			{Mu: c.Rating2Mu(1400), Phi: c.Deviation2Phi(30), Rank: 4},
+			{Mu: c.Rating2Mu(1550), Phi: c.Deviation2Phi(100), Rank: 1},
+			{Mu: c.Rating2Mu(1700), Phi: c.Deviation2Phi(300), Rank: 1},
+		},
+		Rank: 2,
+	}
+	expected := Strength{Mu: c.Rating2Mu(1500), Phi: c.Deviation2Phi(200), Sigma: 0.06}
+
+	if err := e.EstimateMulti(&s, o, 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := e.EstimateBatch(&expected, []Outcome{
+		{Mu: c.Rating2Mu(1400), Phi: c.Deviation2Phi(30), Score: 1},
+		{Mu: c.Rating2Mu(1550), Phi: c.Deviation2Phi(100), Score: 0},
+		{Mu: c.Rating2Mu(1700), Phi: c.Deviation2Phi(300), Score: 0},
+	}, 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if s != expected {
+		t.Fatalf("expected: %v, got: %v", expected, s)
+	}
+}
+
+func TestAggregateTeam(t *testing.T) {
+	c := Converter{
+		Rating:    DefaultRating,
+		Deviation: DefaultDeviation,
+		Factor:    DefaultFactor,
+	}
+
+	team := []Strength{
+		{Mu: c.Rating2Mu(1400), Phi: c.Deviation2Phi(30)},
+		{Mu: c.Rating2Mu(1600), Phi: c.Deviation2Phi(50)},
+	}
+
+	expected := Opponent{
+		Mu:   c.Rating2Mu(1500),
+		Phi:  math.Sqrt((pow2(c.Deviation2Phi(30)) + pow2(c.Deviation2Phi(50))) / 2),
+		Rank: 1,
+	}
+
+	if got := AggregateTeam(team, 1); got != expected {
+		t.Fatalf("expected: %v, got: %v", expected, got)
+	}
+}
+
 func BenchmarkEstimate(b *testing.B) {
 	c := Converter{
 		Rating:    DefaultRating,
@@ -71,6 +351,7 @@ func BenchmarkEstimate(b *testing.B) {
 		MinMu: c.Rating2Mu(10), MaxMu: c.Rating2Mu(5000),
 		MinPhi: c.Deviation2Phi(30), MaxPhi: c.Deviation2Phi(DefaultDeviation),
 		MinSigma: 0.04, MaxSigma: 0.08, Tau: DefaultTau, Epsilon: DefaultEpsilon,
+		MaxIterations: DefaultMaxIterations,
 	}
 
 	for b.Loop() {